@@ -0,0 +1,32 @@
+package collection
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// EncodeGob serializes the collection's contents to a gob-encoded byte
+// slice, for persisting cached state (guilds, members, channels, ...) to
+// disk and reloading it on startup via DecodeGob.
+func (c *Collection[V]) EncodeGob() ([]byte, error) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c.data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeGob loads entries from a byte slice produced by EncodeGob into the
+// collection, keeping any entries already present.
+func (c *Collection[V]) DecodeGob(data []byte) error {
+	var decoded map[string]V
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&decoded); err != nil {
+		return err
+	}
+	for k, v := range decoded {
+		c.Set(k, v)
+	}
+	return nil
+}
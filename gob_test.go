@@ -0,0 +1,46 @@
+package collection
+
+import "testing"
+
+func TestEncodeDecodeGobRoundTrip(t *testing.T) {
+	c := New[int](0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	data, err := c.EncodeGob()
+	if err != nil {
+		t.Fatalf("EncodeGob() error = %v", err)
+	}
+
+	decoded := New[int](0)
+	if err := decoded.DecodeGob(data); err != nil {
+		t.Fatalf("DecodeGob() error = %v", err)
+	}
+
+	if decoded.Size() != c.Size() {
+		t.Fatalf("Size() = %d, want %d", decoded.Size(), c.Size())
+	}
+	if decoded.Get("a") != 1 || decoded.Get("b") != 2 {
+		t.Fatalf("round-tripped values = (%d, %d), want (1, 2)", decoded.Get("a"), decoded.Get("b"))
+	}
+}
+
+func TestDecodeGobKeepsExistingEntries(t *testing.T) {
+	c := New[int](0)
+	c.Set("existing", 9)
+
+	other := New[int](0)
+	other.Set("new", 1)
+	data, err := other.EncodeGob()
+	if err != nil {
+		t.Fatalf("EncodeGob() error = %v", err)
+	}
+
+	if err := c.DecodeGob(data); err != nil {
+		t.Fatalf("DecodeGob() error = %v", err)
+	}
+
+	if c.Get("existing") != 9 || c.Get("new") != 1 {
+		t.Fatalf("got existing=%d new=%d, want existing=9 new=1", c.Get("existing"), c.Get("new"))
+	}
+}
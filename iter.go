@@ -0,0 +1,31 @@
+package collection
+
+// Range is a Go 1.23 range-over-func iterator over the collection's
+// entries, taken under a single read lock:
+//
+//	for k, v := range c.Range {
+//		...
+//	}
+//
+// Iteration stops early if yield returns false.
+func (c *Collection[V]) Range(yield func(key string, value V) bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	for k, v := range c.data {
+		if !yield(k, v) {
+			return
+		}
+	}
+}
+
+// Snapshot returns a copy of the collection's contents as a plain map, safe
+// to read or range over without holding the collection's internal lock.
+func (c *Collection[V]) Snapshot() map[string]V {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	snap := make(map[string]V, len(c.data))
+	for k, v := range c.data {
+		snap[k] = v
+	}
+	return snap
+}
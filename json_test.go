@@ -0,0 +1,39 @@
+package collection
+
+import "testing"
+
+func TestMarshalUnmarshalJSONRoundTrip(t *testing.T) {
+	c := New[int](0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	data, err := c.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	decoded := New[int](0)
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+
+	if decoded.Size() != c.Size() {
+		t.Fatalf("Size() = %d, want %d", decoded.Size(), c.Size())
+	}
+	if decoded.Get("a") != 1 || decoded.Get("b") != 2 {
+		t.Fatalf("round-tripped values = (%d, %d), want (1, 2)", decoded.Get("a"), decoded.Get("b"))
+	}
+}
+
+func TestUnmarshalJSONKeepsExistingEntries(t *testing.T) {
+	c := New[int](0)
+	c.Set("existing", 9)
+
+	if err := c.UnmarshalJSON([]byte(`{"new":1}`)); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+
+	if c.Get("existing") != 9 || c.Get("new") != 1 {
+		t.Fatalf("got existing=%d new=%d, want existing=9 new=1", c.Get("existing"), c.Get("new"))
+	}
+}
@@ -1,15 +1,52 @@
 package collection
 
 import (
+	"container/list"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"sync"
+	"time"
 )
 
 // Collection is a generic collection type
 type Collection[V any] struct {
 	data map[string]V
 	m    sync.RWMutex
+
+	ttlMu        sync.Mutex
+	expiry       expiryHeap
+	expiryItems  map[string]*expiryItem
+	ttlDurations map[string]time.Duration
+
+	sweepInterval time.Duration
+	sweepStop     chan struct{}
+	sweepWG       sync.WaitGroup
+
+	// OnExpire, if set, is called with the key and value of every entry
+	// removed by the background sweeper started via EnableSweepInterval.
+	OnExpire func(key string, value V)
+
+	maxSize int
+	policy  EvictionPolicy
+
+	policyMu    sync.Mutex
+	lruList     *list.List
+	lruElems    map[string]*list.Element
+	freq        map[string]uint64
+	freqBuckets map[uint64]*list.List
+	freqElems   map[string]*list.Element
+	minFreq     uint64
+
+	hits, misses, evictions uint64
+
+	// OnEvict, if set, is called with the key and value of every entry
+	// evicted to keep the collection within its size policy.
+	OnEvict func(key string, value V)
+
+	modeMu       sync.Mutex
+	parallelMode bool
+	workers      int
 }
 
 type CollectionEntry[V any] struct {
@@ -24,25 +61,40 @@ func New[V any](size int) *Collection[V] {
 	}
 }
 
-// Set sets the value for the given key
+// Set sets the value for the given key. If the collection was created with
+// a bounded NewWithPolicy size and is full, the least valuable entry under
+// the configured EvictionPolicy is evicted first.
 func (c *Collection[V]) Set(key string, value V) {
 	c.m.Lock()
-	defer c.m.Unlock()
+	_, exists := c.data[key]
 	c.data[key] = value
+	c.m.Unlock()
+
+	c.recordSet(key, exists)
 }
 
-// Get gets the value for the given key
+// Get gets the value for the given key. An entry past its TTL is treated
+// as absent and lazily removed.
 func (c *Collection[V]) Get(key string) V {
+	if c.expired(key) {
+		c.Delete(key)
+		return *new(V)
+	}
 	c.m.RLock()
-	defer c.m.RUnlock()
-	return c.data[key]
+	v, ok := c.data[key]
+	c.m.RUnlock()
+
+	c.recordGet(key, ok)
+	return v
 }
 
 // Delete deletes the value for the given key
 func (c *Collection[V]) Delete(key string) {
 	c.m.Lock()
-	defer c.m.Unlock()
 	delete(c.data, key)
+	c.m.Unlock()
+	c.clearTTL(key)
+	c.removeFromPolicy(key)
 }
 
 // Size returns the number of elements in the collection
@@ -78,24 +130,56 @@ func (c *Collection[V]) Values() []V {
 	return values
 }
 
-// Each iterates over the collection and calls the callback function for each item
+// Each iterates over the collection and calls the callback function for each
+// item. In Parallel mode, callbacks are fanned out across the worker pool.
 func (c *Collection[V]) Each(f func(key string, value V)) {
 	c.m.RLock()
 	defer c.m.RUnlock()
+	if c.isParallel() {
+		c.eachParallel(f)
+		return
+	}
 	for k, v := range c.data {
 		f(k, v)
 	}
 }
 
-// Clear clears the collection
+// Clear clears the collection, including any TTL and eviction-policy
+// bookkeeping, so a key set after Clear starts with a clean slate instead
+// of inheriting a stale deadline or acting as a ghost eviction victim from
+// before the clear.
 func (c *Collection[V]) Clear() {
 	c.m.Lock()
-	defer c.m.Unlock()
 	c.data = make(map[string]V)
+	c.m.Unlock()
+
+	c.ttlMu.Lock()
+	c.expiry = nil
+	c.expiryItems = nil
+	c.ttlDurations = nil
+	c.ttlMu.Unlock()
+
+	c.policyMu.Lock()
+	if c.lruList != nil {
+		c.lruList = list.New()
+		c.lruElems = make(map[string]*list.Element)
+	}
+	if c.freq != nil {
+		c.freq = make(map[string]uint64)
+		c.freqBuckets = make(map[uint64]*list.List)
+		c.freqElems = make(map[string]*list.Element)
+	}
+	c.minFreq = 0
+	c.policyMu.Unlock()
 }
 
-// Contains returns true if the collection contains the given key
+// Contains returns true if the collection contains the given key and it
+// has not expired.
 func (c *Collection[V]) Contains(key string) bool {
+	if c.expired(key) {
+		c.Delete(key)
+		return false
+	}
 	c.m.RLock()
 	defer c.m.RUnlock()
 	_, ok := c.data[key]
@@ -114,10 +198,15 @@ func (c *Collection[V]) Find(f func(key string, value V) bool) (V, bool) {
 	return *new(V), false
 }
 
-// Filter returns a new collection containing all the elements that satisfy the given predicate
+// Filter returns a new collection containing all the elements that satisfy
+// the given predicate. In Parallel mode, the predicate is evaluated across
+// the worker pool and matches are merged under a mutex.
 func (c *Collection[V]) Filter(f func(key string, value V) bool) *Collection[V] {
 	c.m.RLock()
 	defer c.m.RUnlock()
+	if c.isParallel() {
+		return c.filterParallel(f)
+	}
 	newC := New[V](len(c.data))
 	for k, v := range c.data {
 		if f(k, v) {
@@ -127,10 +216,15 @@ func (c *Collection[V]) Filter(f func(key string, value V) bool) *Collection[V]
 	return newC
 }
 
-// Map returns a new collection containing the results of applying the given function to each element
+// Map returns a new collection containing the results of applying the given
+// function to each element. In Parallel mode, f is evaluated across the
+// worker pool and results are merged under a mutex.
 func (c *Collection[V]) Map(f func(key string, value V) V) *Collection[V] {
 	c.m.RLock()
 	defer c.m.RUnlock()
+	if c.isParallel() {
+		return c.mapParallel(f)
+	}
 	newC := New[V](len(c.data))
 	for k, v := range c.data {
 		newC.Set(k, f(k, v))
@@ -152,25 +246,29 @@ func (c *Collection[V]) Reduce(f func(acc V, value V) V, init V) V {
 // Combines this collection with others into a new collection. None of the source collections are modified.
 func (c *Collection[V]) Concat(others ...*Collection[V]) *Collection[V] {
 	c.m.RLock()
-	defer c.m.RUnlock()
 	newC := New[V](len(c.data))
 	for k, v := range c.data {
 		newC.Set(k, v)
 	}
+	c.m.RUnlock()
+
 	for _, other := range others {
-		other.m.RLock()
-		defer other.m.RUnlock()
-		for k, v := range other.data {
+		other.Each(func(k string, v V) {
 			newC.Set(k, v)
-		}
+		})
 	}
 	return newC
 }
 
-// Every checks if all items passes a test.
+// Every checks if all items passes a test. In Parallel mode, the predicate
+// is evaluated across the worker pool and short-circuits on the first
+// failure.
 func (c *Collection[V]) Every(f func(key string, value V) bool) bool {
 	c.m.RLock()
 	defer c.m.RUnlock()
+	if c.isParallel() {
+		return c.everyParallel(f)
+	}
 	for k, v := range c.data {
 		if !f(k, v) {
 			return false
@@ -179,10 +277,14 @@ func (c *Collection[V]) Every(f func(key string, value V) bool) bool {
 	return true
 }
 
-// Some checks if some items passes a test.
+// Some checks if some items passes a test. In Parallel mode, the predicate
+// is evaluated across the worker pool and short-circuits on the first match.
 func (c *Collection[V]) Some(f func(key string, value V) bool) bool {
 	c.m.RLock()
 	defer c.m.RUnlock()
+	if c.isParallel() {
+		return c.someParallel(f)
+	}
 	for k, v := range c.data {
 		if f(k, v) {
 			return true
@@ -204,71 +306,128 @@ func (c *Collection[V]) Entries() []CollectionEntry[V] {
 	return entries
 }
 
-// Obtains random value from this collection.
-func (c *Collection[V]) Random() V {
+// snapshotEntries returns every entry under a single lock acquisition, so
+// operations that pick entries by position don't race with concurrent
+// mutations between a size check and per-key lookups.
+func (c *Collection[V]) snapshotEntries() []CollectionEntry[V] {
 	c.m.RLock()
 	defer c.m.RUnlock()
-	randomKey := c.Keys()[rand.Intn(c.Size())]
-	return c.data[randomKey]
+	entries := make([]CollectionEntry[V], 0, len(c.data))
+	for k, v := range c.data {
+		entries = append(entries, CollectionEntry[V]{k, v})
+	}
+	return entries
 }
 
-// Sweep removes items that satisfy the provided filter function.
+// Obtains random value from this collection.
+func (c *Collection[V]) Random() V {
+	entries := c.snapshotEntries()
+	if len(entries) == 0 {
+		return *new(V)
+	}
+	return entries[rand.Intn(len(entries))].value
+}
+
+// Sweep removes items that satisfy the provided filter function. In
+// Parallel mode, the filter is evaluated across the worker pool. The write
+// lock is held for the whole pass so keys can't be mutated out from under
+// the deletes.
 func (c *Collection[V]) Sweep(callback func(key string, value V) bool) {
-	c.m.RLock()
-	defer c.m.RUnlock()
-	for key, value := range c.data {
-		if callback(key, value) {
-			c.Delete(key)
+	c.m.Lock()
+	var removed []string
+	if c.isParallel() {
+		removed = c.sweepParallel(callback)
+	} else {
+		for key, value := range c.data {
+			if callback(key, value) {
+				removed = append(removed, key)
+			}
 		}
 	}
+	for _, key := range removed {
+		delete(c.data, key)
+	}
+	c.m.Unlock()
+
+	for _, key := range removed {
+		c.clearTTL(key)
+		c.removeFromPolicy(key)
+	}
 }
 
 // First returns the first element in the collection.
 func (c *Collection[V]) First() V {
-	return c.Get(c.Keys()[0])
+	entries := c.snapshotEntries()
+	if len(entries) == 0 {
+		return *new(V)
+	}
+	return entries[0].value
 }
 
 // FirstN returns the first n elements n the collection
 func (c *Collection[V]) FirstN(n int) *Collection[V] {
+	entries := c.snapshotEntries()
+	if n > len(entries) {
+		n = len(entries)
+	}
 	col := New[V](n)
-	keys := c.Keys()
-
 	for i := 0; i < n; i++ {
-		key := keys[i]
-		value := c.Get(key)
-
-		col.Set(key, value)
+		col.Set(entries[i].key, entries[i].value)
 	}
-
 	return col
 }
 
 // Last returns the last element in the collection.
 func (c *Collection[V]) Last(n ...int) V {
-	return c.Get(c.Keys()[c.Size()-1])
+	entries := c.snapshotEntries()
+	if len(entries) == 0 {
+		return *new(V)
+	}
+	return entries[len(entries)-1].value
 }
 
 // LastN returns the last n elements in the collection.
 func (c *Collection[V]) LastN(n int) *Collection[V] {
+	entries := c.snapshotEntries()
+	if n > len(entries) {
+		n = len(entries)
+	}
 	col := New[V](n)
-	keys := c.Keys()
-	for i := len(keys) - n; i < len(keys); i++ {
-		col.Set(keys[i], c.Get(keys[i]))
-		n--
+	for i := len(entries) - n; i < len(entries); i++ {
+		col.Set(entries[i].key, entries[i].value)
 	}
 	return col
 }
 
-/*
-JSON serializes the contents of the Collection into a JSON-encoded byte slice.
-It represents each element in the collection as an array containing a key (string)
-and a value (of type V).
-*/
-func (c *Collection[V]) JSON() (*[]byte, error) {
-	var jsonArray []interface{}
-	for key, value := range c.data {
-		jsonArray = append(jsonArray, []interface{}{key, value})
-	}
-	b, err := json.Marshal(jsonArray)
-	return &b, err
+// MarshalJSON serializes the collection as a plain JSON object of
+// key/value pairs, e.g. {"123": {...}, "456": {...}}.
+func (c *Collection[V]) MarshalJSON() ([]byte, error) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return json.Marshal(c.data)
+}
+
+// UnmarshalJSON populates the collection from a JSON object of key/value
+// pairs, the inverse of MarshalJSON. Each value is decoded individually so
+// unmarshal errors can be attributed to a key.
+func (c *Collection[V]) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.m.Lock()
+	if c.data == nil {
+		c.data = make(map[string]V, len(raw))
+	}
+	c.m.Unlock()
+
+	for key, msg := range raw {
+		var v V
+		if err := json.Unmarshal(msg, &v); err != nil {
+			return fmt.Errorf("collection: key %q: %w", key, err)
+		}
+		c.Set(key, v)
+	}
+	return nil
 }
@@ -0,0 +1,228 @@
+package collection
+
+import "sort"
+
+// GroupBy splits c into groups keyed by the result of f. Go methods can't
+// introduce their own type parameters, so, like UniqBy and CountBy, this is
+// a package-level function rather than a method.
+func GroupBy[V any, K comparable](c *Collection[V], f func(key string, value V) K) map[K]*Collection[V] {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	groups := make(map[K]*Collection[V])
+	for k, v := range c.data {
+		group := f(k, v)
+		col, ok := groups[group]
+		if !ok {
+			col = New[V](0)
+			groups[group] = col
+		}
+		col.Set(k, v)
+	}
+	return groups
+}
+
+// UniqBy returns a new collection keeping only the first entry seen for
+// each distinct result of f.
+func UniqBy[V any, K comparable](c *Collection[V], f func(value V) K) *Collection[V] {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	newC := New[V](len(c.data))
+	seen := make(map[K]struct{}, len(c.data))
+	for k, v := range c.data {
+		key := f(v)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		newC.Set(k, v)
+	}
+	return newC
+}
+
+// CountBy returns the number of entries for each distinct result of f.
+func CountBy[V any, K comparable](c *Collection[V], f func(value V) K) map[K]int {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	counts := make(map[K]int)
+	for _, v := range c.data {
+		counts[f(v)]++
+	}
+	return counts
+}
+
+// Partition splits the collection into two new collections: pass holds the
+// entries that satisfy f, fail holds the rest. The source is unmodified.
+func (c *Collection[V]) Partition(f func(key string, value V) bool) (pass, fail *Collection[V]) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	pass = New[V](len(c.data))
+	fail = New[V](len(c.data))
+	for k, v := range c.data {
+		if f(k, v) {
+			pass.Set(k, v)
+		} else {
+			fail.Set(k, v)
+		}
+	}
+	return pass, fail
+}
+
+// Chunk splits the collection into new collections of at most n entries
+// each. The source is unmodified.
+func (c *Collection[V]) Chunk(n int) []*Collection[V] {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	if n < 1 {
+		n = 1
+	}
+	chunks := make([]*Collection[V], 0, (len(c.data)+n-1)/n)
+	current := New[V](n)
+	for k, v := range c.data {
+		if current.Size() == n {
+			chunks = append(chunks, current)
+			current = New[V](n)
+		}
+		current.Set(k, v)
+	}
+	if current.Size() > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// SortBy returns the collection's entries sorted by less. The source is
+// unmodified.
+func (c *Collection[V]) SortBy(less func(a, b V) bool) []CollectionEntry[V] {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	entries := make([]CollectionEntry[V], 0, len(c.data))
+	for k, v := range c.data {
+		entries = append(entries, CollectionEntry[V]{k, v})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i].value, entries[j].value)
+	})
+	return entries
+}
+
+// KeyBy returns a new collection with entries re-keyed by the result of f,
+// discarding their original keys. The source is unmodified.
+func (c *Collection[V]) KeyBy(f func(value V) string) *Collection[V] {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	newC := New[V](len(c.data))
+	for _, v := range c.data {
+		newC.Set(f(v), v)
+	}
+	return newC
+}
+
+// Reject returns a new collection containing every element that does NOT
+// satisfy f, the inverse of Filter. The source is unmodified.
+func (c *Collection[V]) Reject(f func(key string, value V) bool) *Collection[V] {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	newC := New[V](len(c.data))
+	for k, v := range c.data {
+		if !f(k, v) {
+			newC.Set(k, v)
+		}
+	}
+	return newC
+}
+
+// Tap calls f once for every entry for its side effects, then returns the
+// receiver unmodified so it can be chained with other operators.
+func (c *Collection[V]) Tap(f func(key string, value V)) *Collection[V] {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	for k, v := range c.data {
+		f(k, v)
+	}
+	return c
+}
+
+// otherKeySets snapshots each of others' keys independently of c's lock, so
+// Difference/Intersect never call back into c.Contains/c.Each while c's own
+// lock is held (calling those set ops with c itself as one of others would
+// otherwise self-deadlock on the first lazily-expired TTL entry).
+func otherKeySets[V any](others []*Collection[V]) []map[string]struct{} {
+	sets := make([]map[string]struct{}, len(others))
+	for i, other := range others {
+		snap := other.Snapshot()
+		keys := make(map[string]struct{}, len(snap))
+		for k := range snap {
+			keys[k] = struct{}{}
+		}
+		sets[i] = keys
+	}
+	return sets
+}
+
+// Difference returns a new collection containing the entries of c whose
+// keys are not present in any of others. The sources are unmodified.
+func (c *Collection[V]) Difference(others ...*Collection[V]) *Collection[V] {
+	sets := otherKeySets(others)
+
+	c.m.RLock()
+	defer c.m.RUnlock()
+	newC := New[V](len(c.data))
+	for k, v := range c.data {
+		found := false
+		for _, keys := range sets {
+			if _, ok := keys[k]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			newC.Set(k, v)
+		}
+	}
+	return newC
+}
+
+// Intersect returns a new collection containing the entries of c whose
+// keys are present in every one of others. The sources are unmodified.
+func (c *Collection[V]) Intersect(others ...*Collection[V]) *Collection[V] {
+	sets := otherKeySets(others)
+
+	c.m.RLock()
+	defer c.m.RUnlock()
+	newC := New[V](len(c.data))
+	for k, v := range c.data {
+		inAll := true
+		for _, keys := range sets {
+			if _, ok := keys[k]; !ok {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			newC.Set(k, v)
+		}
+	}
+	return newC
+}
+
+// Union returns a new collection containing the entries of c and others,
+// keeping c's value on key collisions. The sources are unmodified.
+func (c *Collection[V]) Union(others ...*Collection[V]) *Collection[V] {
+	snapshots := make([]map[string]V, len(others))
+	for i, other := range others {
+		snapshots[i] = other.Snapshot()
+	}
+
+	c.m.RLock()
+	defer c.m.RUnlock()
+	newC := New[V](len(c.data))
+	for _, snap := range snapshots {
+		for k, v := range snap {
+			newC.Set(k, v)
+		}
+	}
+	for k, v := range c.data {
+		newC.Set(k, v)
+	}
+	return newC
+}
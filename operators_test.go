@@ -0,0 +1,243 @@
+package collection
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupBy(t *testing.T) {
+	c := New[int](0)
+	for i := 0; i < 10; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	groups := GroupBy(c, func(key string, value int) string {
+		if value%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+
+	if groups["even"].Size() != 5 || groups["odd"].Size() != 5 {
+		t.Fatalf("group sizes = %d/%d, want 5/5", groups["even"].Size(), groups["odd"].Size())
+	}
+}
+
+func TestUniqBy(t *testing.T) {
+	c := New[int](0)
+	c.Set("a", 1)
+	c.Set("b", 1)
+	c.Set("c", 2)
+
+	uniq := UniqBy(c, func(value int) int { return value })
+
+	if uniq.Size() != 2 {
+		t.Fatalf("Size() = %d, want 2", uniq.Size())
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	c := New[int](0)
+	c.Set("a", 1)
+	c.Set("b", 1)
+	c.Set("c", 2)
+
+	counts := CountBy(c, func(value int) int { return value })
+
+	if counts[1] != 2 || counts[2] != 1 {
+		t.Fatalf("counts = %v, want map[1:2 2:1]", counts)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	c := New[int](0)
+	for i := 0; i < 10; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	pass, fail := c.Partition(func(key string, value int) bool { return value%2 == 0 })
+
+	if pass.Size() != 5 || fail.Size() != 5 {
+		t.Fatalf("pass/fail sizes = %d/%d, want 5/5", pass.Size(), fail.Size())
+	}
+}
+
+func TestChunk(t *testing.T) {
+	c := New[int](0)
+	for i := 0; i < 5; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	chunks := c.Chunk(2)
+
+	if len(chunks) != 3 {
+		t.Fatalf("len(chunks) = %d, want 3", len(chunks))
+	}
+	total := 0
+	for _, chunk := range chunks {
+		if chunk.Size() > 2 {
+			t.Fatalf("chunk size = %d, want <= 2", chunk.Size())
+		}
+		total += chunk.Size()
+	}
+	if total != 5 {
+		t.Fatalf("total entries across chunks = %d, want 5", total)
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	c := New[int](0)
+	c.Set("a", 3)
+	c.Set("b", 1)
+	c.Set("c", 2)
+
+	entries := c.SortBy(func(a, b int) bool { return a < b })
+
+	if len(entries) != 3 {
+		t.Fatalf("len(entries) = %d, want 3", len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].value > entries[i].value {
+			t.Fatalf("entries not sorted ascending: %v", entries)
+		}
+	}
+}
+
+func TestKeyBy(t *testing.T) {
+	c := New[int](0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	keyed := c.KeyBy(func(value int) string { return "k" + strconv.Itoa(value) })
+
+	if !keyed.Contains("k1") || !keyed.Contains("k2") {
+		t.Fatal("expected entries re-keyed by value")
+	}
+}
+
+func TestReject(t *testing.T) {
+	c := New[int](0)
+	for i := 0; i < 10; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	rejected := c.Reject(func(key string, value int) bool { return value%2 == 0 })
+
+	if rejected.Size() != 5 {
+		t.Fatalf("Size() = %d, want 5", rejected.Size())
+	}
+	rejected.Each(func(key string, value int) {
+		if value%2 == 0 {
+			t.Fatalf("Reject kept an even value %d", value)
+		}
+	})
+}
+
+func TestTap(t *testing.T) {
+	c := New[int](0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	sum := 0
+	returned := c.Tap(func(key string, value int) { sum += value })
+
+	if sum != 3 {
+		t.Fatalf("sum = %d, want 3", sum)
+	}
+	if returned != c {
+		t.Fatal("Tap should return the receiver unmodified")
+	}
+}
+
+func TestDifference(t *testing.T) {
+	a := New[int](0)
+	a.Set("x", 1)
+	a.Set("y", 2)
+	b := New[int](0)
+	b.Set("y", 2)
+
+	diff := a.Difference(b)
+
+	if !diff.Contains("x") || diff.Contains("y") {
+		t.Fatalf("Difference = %v, want only x", diff.Keys())
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	a := New[int](0)
+	a.Set("x", 1)
+	a.Set("y", 2)
+	b := New[int](0)
+	b.Set("y", 2)
+	b.Set("z", 3)
+
+	inter := a.Intersect(b)
+
+	if !inter.Contains("y") || inter.Contains("x") || inter.Contains("z") {
+		t.Fatalf("Intersect = %v, want only y", inter.Keys())
+	}
+}
+
+func TestUnion(t *testing.T) {
+	a := New[int](0)
+	a.Set("x", 1)
+	b := New[int](0)
+	b.Set("y", 2)
+
+	union := a.Union(b)
+
+	if !union.Contains("x") || !union.Contains("y") {
+		t.Fatalf("Union = %v, want x and y", union.Keys())
+	}
+}
+
+// TestDifferenceSelfReferenceDoesNotDeadlock is a regression test for the
+// self-deadlock fixed by snapshotting others first (otherKeySets for
+// Difference and Intersect, Snapshot for Union): calling one of them with
+// the receiver itself as one of others used to recurse into Contains/Each
+// while c's own read lock was already held. Every other collection is now
+// snapshotted before c.m.RLock() is taken, so this must complete even with
+// a concurrent writer churning the collection.
+func TestDifferenceSelfReferenceDoesNotDeadlock(t *testing.T) {
+	c := New[int](0)
+	for i := 0; i < 50; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				key := strconv.Itoa(i % 50)
+				c.Set(key, i)
+				c.Delete(key)
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	done := make(chan *Collection[int])
+	go func() {
+		done <- c.Difference(c)
+	}()
+
+	select {
+	case result := <-done:
+		if result.Size() != 0 {
+			t.Fatalf("Difference(c) Size() = %d, want 0", result.Size())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Difference(c) did not return, possible self-reference deadlock")
+	}
+}
@@ -0,0 +1,268 @@
+package collection
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Parallel enables parallel execution for Each, Map, Filter, Some, Every,
+// and Sweep, fanning work out across a bounded worker pool. It returns the
+// receiver for chaining, e.g. c.Parallel().Each(f). Sequential is the
+// default.
+func (c *Collection[V]) Parallel() *Collection[V] {
+	c.modeMu.Lock()
+	c.parallelMode = true
+	c.modeMu.Unlock()
+	return c
+}
+
+// Sequential disables parallel execution, restoring the default behavior.
+// It returns the receiver for chaining.
+func (c *Collection[V]) Sequential() *Collection[V] {
+	c.modeMu.Lock()
+	c.parallelMode = false
+	c.modeMu.Unlock()
+	return c
+}
+
+// WithWorkers sets the worker pool size used while Parallel mode is
+// enabled. It returns the receiver for chaining. An n below 1 is treated
+// as 1.
+func (c *Collection[V]) WithWorkers(n int) *Collection[V] {
+	if n < 1 {
+		n = 1
+	}
+	c.modeMu.Lock()
+	c.workers = n
+	c.modeMu.Unlock()
+	return c
+}
+
+func (c *Collection[V]) isParallel() bool {
+	c.modeMu.Lock()
+	defer c.modeMu.Unlock()
+	return c.parallelMode
+}
+
+func (c *Collection[V]) workerCount() int {
+	c.modeMu.Lock()
+	n := c.workers
+	c.modeMu.Unlock()
+	if n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// eachParallel runs f over every entry using the worker pool. Callers must
+// hold at least c.m.RLock().
+func (c *Collection[V]) eachParallel(f func(key string, value V)) {
+	jobs := make(chan CollectionEntry[V])
+	var wg sync.WaitGroup
+	workers := c.workerCount()
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				f(job.key, job.value)
+			}
+		}()
+	}
+	for k, v := range c.data {
+		jobs <- CollectionEntry[V]{k, v}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// filterParallel evaluates f over every entry using the worker pool,
+// merging matches into the result under a mutex. Callers must hold at
+// least c.m.RLock().
+func (c *Collection[V]) filterParallel(f func(key string, value V) bool) *Collection[V] {
+	newC := New[V](len(c.data))
+	jobs := make(chan CollectionEntry[V])
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	workers := c.workerCount()
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if f(job.key, job.value) {
+					mu.Lock()
+					newC.data[job.key] = job.value
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for k, v := range c.data {
+		jobs <- CollectionEntry[V]{k, v}
+	}
+	close(jobs)
+	wg.Wait()
+	return newC
+}
+
+// mapParallel applies f over every entry using the worker pool, merging
+// results into the new collection under a mutex. Callers must hold at
+// least c.m.RLock().
+func (c *Collection[V]) mapParallel(f func(key string, value V) V) *Collection[V] {
+	newC := New[V](len(c.data))
+	jobs := make(chan CollectionEntry[V])
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	workers := c.workerCount()
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				result := f(job.key, job.value)
+				mu.Lock()
+				newC.data[job.key] = result
+				mu.Unlock()
+			}
+		}()
+	}
+	for k, v := range c.data {
+		jobs <- CollectionEntry[V]{k, v}
+	}
+	close(jobs)
+	wg.Wait()
+	return newC
+}
+
+// everyParallel checks whether every entry passes f, short-circuiting the
+// remaining work via context cancellation on the first failure. Callers
+// must hold at least c.m.RLock().
+func (c *Collection[V]) everyParallel(f func(key string, value V) bool) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var failed int32
+	jobs := make(chan CollectionEntry[V])
+	var wg sync.WaitGroup
+	workers := c.workerCount()
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job, ok := <-jobs:
+					if !ok {
+						return
+					}
+					if !f(job.key, job.value) {
+						atomic.StoreInt32(&failed, 1)
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+feed:
+	for k, v := range c.data {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- CollectionEntry[V]{k, v}:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return atomic.LoadInt32(&failed) == 0
+}
+
+// someParallel checks whether any entry passes f, short-circuiting the
+// remaining work via context cancellation on the first match. Callers must
+// hold at least c.m.RLock().
+func (c *Collection[V]) someParallel(f func(key string, value V) bool) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var found int32
+	jobs := make(chan CollectionEntry[V])
+	var wg sync.WaitGroup
+	workers := c.workerCount()
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job, ok := <-jobs:
+					if !ok {
+						return
+					}
+					if f(job.key, job.value) {
+						atomic.StoreInt32(&found, 1)
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+feed:
+	for k, v := range c.data {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- CollectionEntry[V]{k, v}:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return atomic.LoadInt32(&found) == 1
+}
+
+// sweepParallel evaluates callback over every entry using the worker pool
+// and returns the keys that matched, for the caller to delete. Callers
+// must hold c.m.Lock() (the write lock) for the duration, since Sweep owns
+// removal of the matched keys itself.
+func (c *Collection[V]) sweepParallel(callback func(key string, value V) bool) []string {
+	jobs := make(chan CollectionEntry[V])
+	results := make(chan string)
+	var wg sync.WaitGroup
+	workers := c.workerCount()
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if callback(job.key, job.value) {
+					results <- job.key
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	go func() {
+		for k, v := range c.data {
+			jobs <- CollectionEntry[V]{k, v}
+		}
+		close(jobs)
+	}()
+
+	var removed []string
+	for key := range results {
+		removed = append(removed, key)
+	}
+	return removed
+}
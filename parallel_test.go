@@ -0,0 +1,145 @@
+package collection
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func newMemberCollection(n int) *Collection[int] {
+	c := New[int](n)
+	for i := 0; i < n; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	return c
+}
+
+func TestParallelMapMatchesSequential(t *testing.T) {
+	seq := newMemberCollection(200)
+	par := newMemberCollection(200).Parallel().WithWorkers(8)
+
+	double := func(key string, value int) int { return value * 2 }
+
+	seqResult := seq.Map(double)
+	parResult := par.Map(double)
+
+	if seqResult.Size() != parResult.Size() {
+		t.Fatalf("size mismatch: sequential %d, parallel %d", seqResult.Size(), parResult.Size())
+	}
+	seqResult.Each(func(key string, value int) {
+		if parResult.Get(key) != value {
+			t.Fatalf("key %q: sequential %d != parallel %d", key, value, parResult.Get(key))
+		}
+	})
+}
+
+func TestParallelFilterMatchesSequential(t *testing.T) {
+	even := func(key string, value int) bool { return value%2 == 0 }
+
+	seq := newMemberCollection(200).Filter(even)
+	par := newMemberCollection(200).Parallel().Filter(even)
+
+	if seq.Size() != par.Size() {
+		t.Fatalf("size mismatch: sequential %d, parallel %d", seq.Size(), par.Size())
+	}
+}
+
+func TestParallelEveryShortCircuits(t *testing.T) {
+	c := newMemberCollection(100).Parallel()
+	if c.Every(func(key string, value int) bool { return value < 50 }) {
+		t.Fatal("Every returned true, want false since not all values are < 50")
+	}
+}
+
+func TestParallelSomeShortCircuits(t *testing.T) {
+	c := newMemberCollection(100).Parallel()
+	if !c.Some(func(key string, value int) bool { return value == 99 }) {
+		t.Fatal("Some returned false, want true since 99 is present")
+	}
+	if c.Some(func(key string, value int) bool { return value == 1000 }) {
+		t.Fatal("Some returned true, want false since 1000 is absent")
+	}
+}
+
+func TestParallelSweepMatchesSequential(t *testing.T) {
+	removeOdd := func(key string, value int) bool { return value%2 != 0 }
+
+	seq := newMemberCollection(200)
+	seq.Sweep(removeOdd)
+
+	par := newMemberCollection(200).Parallel()
+	par.Sweep(removeOdd)
+
+	if seq.Size() != par.Size() {
+		t.Fatalf("size mismatch: sequential %d, parallel %d", seq.Size(), par.Size())
+	}
+}
+
+func TestSequentialIsDefault(t *testing.T) {
+	c := New[int](0)
+	if c.isParallel() {
+		t.Fatal("a freshly constructed Collection should default to sequential mode")
+	}
+}
+
+// BenchmarkMapSequential and BenchmarkMapParallel demonstrate the speedup
+// Parallel mode gives on a large guild/member-sized collection.
+func benchmarkMap(b *testing.B, size int, parallel bool) {
+	c := newMemberCollection(size)
+	if parallel {
+		c.Parallel()
+	}
+	square := func(key string, value int) int { return value * value }
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Map(square)
+	}
+}
+
+func BenchmarkMapSequential(b *testing.B) {
+	for _, size := range []int{1_000, 10_000} {
+		b.Run(fmt.Sprintf("n=%d", size), func(b *testing.B) {
+			benchmarkMap(b, size, false)
+		})
+	}
+}
+
+func BenchmarkMapParallel(b *testing.B) {
+	for _, size := range []int{1_000, 10_000} {
+		b.Run(fmt.Sprintf("n=%d", size), func(b *testing.B) {
+			benchmarkMap(b, size, true)
+		})
+	}
+}
+
+func benchmarkEach(b *testing.B, size int, parallel bool) {
+	c := newMemberCollection(size)
+	if parallel {
+		c.Parallel()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		c.Each(func(key string, value int) {
+			sum += value
+		})
+	}
+}
+
+func BenchmarkEachSequential(b *testing.B) {
+	for _, size := range []int{1_000, 10_000} {
+		b.Run(fmt.Sprintf("n=%d", size), func(b *testing.B) {
+			benchmarkEach(b, size, false)
+		})
+	}
+}
+
+func BenchmarkEachParallel(b *testing.B) {
+	for _, size := range []int{1_000, 10_000} {
+		b.Run(fmt.Sprintf("n=%d", size), func(b *testing.B) {
+			benchmarkEach(b, size, true)
+		})
+	}
+}
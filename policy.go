@@ -0,0 +1,232 @@
+package collection
+
+import (
+	"container/list"
+	"sync/atomic"
+)
+
+// EvictionPolicy controls how a size-bounded Collection chooses which entry
+// to remove when a Set would otherwise exceed its configured capacity.
+type EvictionPolicy int
+
+const (
+	// PolicyUnbounded never evicts; the collection grows without limit.
+	PolicyUnbounded EvictionPolicy = iota
+	// PolicyLRU evicts the least recently used entry.
+	PolicyLRU
+	// PolicyLFU evicts the least frequently used entry.
+	PolicyLFU
+)
+
+// NewWithPolicy creates a Collection capped at size entries, evicting
+// automatically on Set according to policy once full. size is also used as
+// the initial map capacity, as in New.
+func NewWithPolicy[V any](size int, policy EvictionPolicy) *Collection[V] {
+	c := New[V](size)
+	c.maxSize = size
+	c.policy = policy
+
+	switch policy {
+	case PolicyLRU:
+		c.lruList = list.New()
+		c.lruElems = make(map[string]*list.Element, size)
+	case PolicyLFU:
+		c.freq = make(map[string]uint64, size)
+		c.freqBuckets = make(map[uint64]*list.List)
+		c.freqElems = make(map[string]*list.Element, size)
+	}
+	return c
+}
+
+// Stats returns the cumulative hit, miss, and eviction counters for a
+// Collection created with NewWithPolicy.
+func (c *Collection[V]) Stats() (hits, misses, evictions uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses), atomic.LoadUint64(&c.evictions)
+}
+
+// recordGet updates hit/miss counters and access-order bookkeeping for key.
+func (c *Collection[V]) recordGet(key string, hit bool) {
+	if hit {
+		atomic.AddUint64(&c.hits, 1)
+	} else {
+		atomic.AddUint64(&c.misses, 1)
+	}
+	if c.policy == PolicyUnbounded || !hit {
+		return
+	}
+
+	c.policyMu.Lock()
+	defer c.policyMu.Unlock()
+	switch c.policy {
+	case PolicyLRU:
+		c.touchLRULocked(key)
+	case PolicyLFU:
+		c.touchLFULocked(key)
+	}
+}
+
+// recordSet updates eviction bookkeeping after a Set and evicts an entry if
+// the collection is now over capacity. exists reports whether key already
+// had a value before this Set. The victim is picked before key is recorded,
+// so a newly added key is never chosen as its own victim.
+func (c *Collection[V]) recordSet(key string, exists bool) {
+	if c.policy == PolicyUnbounded {
+		return
+	}
+
+	c.policyMu.Lock()
+	evictKey, shouldEvict := "", false
+	if !exists && c.maxSize > 0 && c.Size() > c.maxSize {
+		evictKey, shouldEvict = c.victimLocked()
+	}
+	switch c.policy {
+	case PolicyLRU:
+		c.touchLRULocked(key)
+	case PolicyLFU:
+		if exists {
+			c.touchLFULocked(key)
+		} else {
+			c.insertLFULocked(key)
+		}
+	}
+	c.policyMu.Unlock()
+
+	if shouldEvict {
+		c.evict(evictKey)
+	}
+}
+
+// touchLRULocked marks key as most recently used. c.policyMu must be held.
+func (c *Collection[V]) touchLRULocked(key string) {
+	if elem, ok := c.lruElems[key]; ok {
+		c.lruList.MoveToFront(elem)
+		return
+	}
+	c.lruElems[key] = c.lruList.PushFront(key)
+}
+
+// insertLFULocked records a brand new key with a frequency of 1, placed at
+// the front of that bucket's recency list. c.policyMu must be held.
+func (c *Collection[V]) insertLFULocked(key string) {
+	c.freq[key] = 1
+	c.freqElems[key] = c.bucketLocked(1).PushFront(key)
+	c.minFreq = 1
+}
+
+// touchLFULocked increments key's use frequency, moving it to the front of
+// the next bucket's recency list. c.policyMu must be held.
+func (c *Collection[V]) touchLFULocked(key string) {
+	n, ok := c.freq[key]
+	if !ok {
+		c.insertLFULocked(key)
+		return
+	}
+	c.removeFromBucketLocked(n, key)
+
+	c.freq[key] = n + 1
+	c.freqElems[key] = c.bucketLocked(n + 1).PushFront(key)
+}
+
+// bucketLocked returns the recency list for frequency n, creating it if
+// necessary. c.policyMu must be held.
+func (c *Collection[V]) bucketLocked(n uint64) *list.List {
+	if c.freqBuckets[n] == nil {
+		c.freqBuckets[n] = list.New()
+	}
+	return c.freqBuckets[n]
+}
+
+// removeFromBucketLocked removes key from frequency bucket n, bumping
+// minFreq past it if that bucket is now empty and was the minimum.
+// c.policyMu must be held.
+func (c *Collection[V]) removeFromBucketLocked(n uint64, key string) {
+	bucket := c.freqBuckets[n]
+	if bucket == nil {
+		return
+	}
+	if elem, ok := c.freqElems[key]; ok {
+		bucket.Remove(elem)
+	}
+	if bucket.Len() == 0 {
+		delete(c.freqBuckets, n)
+		if c.minFreq == n {
+			c.minFreq++
+		}
+	}
+}
+
+// removePolicyLocked removes key from whichever eviction bookkeeping
+// structure the current policy uses, without touching c.data. It is safe
+// to call for a key the policy has never seen. c.policyMu must be held.
+func (c *Collection[V]) removePolicyLocked(key string) {
+	switch c.policy {
+	case PolicyLRU:
+		if elem, ok := c.lruElems[key]; ok {
+			c.lruList.Remove(elem)
+			delete(c.lruElems, key)
+		}
+	case PolicyLFU:
+		if n, ok := c.freq[key]; ok {
+			c.removeFromBucketLocked(n, key)
+			delete(c.freq, key)
+			delete(c.freqElems, key)
+		}
+	}
+}
+
+// removeFromPolicy removes key from the eviction bookkeeping of a
+// NewWithPolicy collection. It must be called whenever a key leaves
+// c.data outside of Set/evict (Delete, TTL expiry, Sweep), or the policy's
+// structures keep a "ghost" entry that can be picked as a future victim
+// and silently no-op in evict, letting the collection exceed maxSize. It
+// is a no-op for a PolicyUnbounded collection.
+func (c *Collection[V]) removeFromPolicy(key string) {
+	if c.policy == PolicyUnbounded {
+		return
+	}
+	c.policyMu.Lock()
+	c.removePolicyLocked(key)
+	c.policyMu.Unlock()
+}
+
+// victimLocked picks the entry to evict under the current policy.
+// c.policyMu must be held.
+func (c *Collection[V]) victimLocked() (string, bool) {
+	switch c.policy {
+	case PolicyLRU:
+		back := c.lruList.Back()
+		if back == nil {
+			return "", false
+		}
+		key := back.Value.(string)
+		c.removePolicyLocked(key)
+		return key, true
+	case PolicyLFU:
+		bucket := c.freqBuckets[c.minFreq]
+		if bucket == nil || bucket.Len() == 0 {
+			return "", false
+		}
+		key := bucket.Back().Value.(string)
+		c.removePolicyLocked(key)
+		return key, true
+	default:
+		return "", false
+	}
+}
+
+// evict removes key from the collection and reports it to OnEvict.
+func (c *Collection[V]) evict(key string) {
+	c.m.Lock()
+	v, ok := c.data[key]
+	delete(c.data, key)
+	c.m.Unlock()
+	if !ok {
+		return
+	}
+
+	atomic.AddUint64(&c.evictions, 1)
+	c.clearTTL(key)
+	if c.OnEvict != nil {
+		c.OnEvict(key, v)
+	}
+}
@@ -0,0 +1,130 @@
+package collection
+
+import "testing"
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewWithPolicy[int](2, PolicyLRU)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // touch a, making b the least recently used
+	c.Set("c", 3)
+
+	if c.Contains("b") {
+		t.Fatal("expected b to be evicted as least recently used")
+	}
+	if !c.Contains("a") || !c.Contains("c") {
+		t.Fatal("expected a and c to remain in the collection")
+	}
+	if c.Size() != 2 {
+		t.Fatalf("Size() = %d, want 2", c.Size())
+	}
+}
+
+func TestLFUEvictsLeastFrequentlyUsed(t *testing.T) {
+	c := NewWithPolicy[int](2, PolicyLFU)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Get("a")
+	c.Set("c", 3)
+
+	if c.Contains("b") {
+		t.Fatal("expected b to be evicted as least frequently used")
+	}
+	if !c.Contains("a") || !c.Contains("c") {
+		t.Fatal("expected a and c to remain in the collection")
+	}
+}
+
+// TestLFUTieBreaksByRecencyNotMapOrder guards against picking a random
+// element of the min-frequency bucket (map iteration order) as the victim,
+// which previously could evict the key a caller had just inserted before
+// it was ever read.
+func TestLFUTieBreaksByRecencyNotMapOrder(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		c := NewWithPolicy[int](1, PolicyLFU)
+		c.Set("a", 1)
+		c.Set("b", 2)
+
+		if !c.Contains("b") {
+			t.Fatalf("trial %d: freshly inserted key b was evicted before being read", i)
+		}
+	}
+}
+
+func TestDeleteDoesNotLeaveGhostPolicyEntry(t *testing.T) {
+	for _, policy := range []EvictionPolicy{PolicyLRU, PolicyLFU} {
+		c := NewWithPolicy[int](2, policy)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Delete("a")
+		c.Set("c", 3)
+		c.Set("d", 4)
+
+		if c.Size() > 2 {
+			t.Fatalf("policy %v: Size() = %d after Delete+Set churn, want <= 2", policy, c.Size())
+		}
+	}
+}
+
+func TestSweepDoesNotLeaveGhostPolicyEntry(t *testing.T) {
+	c := NewWithPolicy[int](2, PolicyLRU)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Sweep(func(key string, value int) bool { return key == "a" })
+	c.Set("c", 3)
+	c.Set("d", 4)
+
+	if c.Size() > 2 {
+		t.Fatalf("Size() = %d after Sweep+Set churn, want <= 2", c.Size())
+	}
+}
+
+func TestClearDoesNotLeaveGhostPolicyEntry(t *testing.T) {
+	c := NewWithPolicy[int](2, PolicyLRU)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Clear()
+	c.Set("x", 1)
+	c.Set("y", 2)
+	c.Set("z", 3)
+
+	if c.Size() > 2 {
+		t.Fatalf("Size() = %d after Clear+Set churn, want <= 2", c.Size())
+	}
+}
+
+func TestStatsTracksHitsMissesAndEvictions(t *testing.T) {
+	c := NewWithPolicy[int](1, PolicyLRU)
+	c.Set("a", 1)
+	c.Get("a")    // hit
+	c.Get("b")    // miss
+	c.Set("b", 2) // evicts a
+
+	hits, misses, evictions := c.Stats()
+	if hits != 1 {
+		t.Errorf("hits = %d, want 1", hits)
+	}
+	if misses != 1 {
+		t.Errorf("misses = %d, want 1", misses)
+	}
+	if evictions != 1 {
+		t.Errorf("evictions = %d, want 1", evictions)
+	}
+}
+
+func TestOnEvictIsCalled(t *testing.T) {
+	c := NewWithPolicy[int](1, PolicyLRU)
+	var evictedKey string
+	var evictedValue int
+	c.OnEvict = func(key string, value int) {
+		evictedKey, evictedValue = key, value
+	}
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if evictedKey != "a" || evictedValue != 1 {
+		t.Fatalf("OnEvict got (%q, %d), want (%q, %d)", evictedKey, evictedValue, "a", 1)
+	}
+}
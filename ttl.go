@@ -0,0 +1,227 @@
+package collection
+
+import (
+	"container/heap"
+	"time"
+)
+
+// expiryItem is an entry in a Collection's expiry heap, ordered by deadline.
+type expiryItem struct {
+	deadline time.Time
+	key      string
+	index    int
+}
+
+// expiryHeap is a min-heap of expiryItem ordered by deadline, so the
+// sweeper can find the next entry to expire without scanning the map.
+type expiryHeap []*expiryItem
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].deadline.Before(h[j].deadline) }
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *expiryHeap) Push(x any) {
+	item := x.(*expiryItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
+
+// SetWithTTL sets the value for the given key and marks it to expire after ttl.
+func (c *Collection[V]) SetWithTTL(key string, value V, ttl time.Duration) {
+	c.Set(key, value)
+
+	c.ttlMu.Lock()
+	defer c.ttlMu.Unlock()
+	c.scheduleLocked(key, ttl)
+}
+
+// TTL returns the remaining time to live for key and true, or false if key
+// has no expiry set.
+func (c *Collection[V]) TTL(key string) (time.Duration, bool) {
+	c.ttlMu.Lock()
+	defer c.ttlMu.Unlock()
+	item, ok := c.expiryItems[key]
+	if !ok {
+		return 0, false
+	}
+	return time.Until(item.deadline), true
+}
+
+// Touch resets key's expiry deadline to its original TTL, extending its life.
+// It is a no-op if key has no TTL set.
+func (c *Collection[V]) Touch(key string) {
+	c.ttlMu.Lock()
+	defer c.ttlMu.Unlock()
+	item, ok := c.expiryItems[key]
+	if !ok {
+		return
+	}
+	ttl, ok := c.ttlDurations[key]
+	if !ok {
+		return
+	}
+	item.deadline = time.Now().Add(ttl)
+	heap.Fix(&c.expiry, item.index)
+}
+
+// scheduleLocked registers or refreshes key's expiry deadline. c.ttlMu must
+// be held by the caller.
+func (c *Collection[V]) scheduleLocked(key string, ttl time.Duration) {
+	if c.expiryItems == nil {
+		c.expiryItems = make(map[string]*expiryItem)
+		c.ttlDurations = make(map[string]time.Duration)
+	}
+	c.ttlDurations[key] = ttl
+	deadline := time.Now().Add(ttl)
+	if item, ok := c.expiryItems[key]; ok {
+		item.deadline = deadline
+		heap.Fix(&c.expiry, item.index)
+		return
+	}
+	item := &expiryItem{deadline: deadline, key: key}
+	heap.Push(&c.expiry, item)
+	c.expiryItems[key] = item
+}
+
+// expired reports whether key has a TTL deadline that has passed.
+func (c *Collection[V]) expired(key string) bool {
+	c.ttlMu.Lock()
+	defer c.ttlMu.Unlock()
+	item, ok := c.expiryItems[key]
+	if !ok {
+		return false
+	}
+	return !item.deadline.After(time.Now())
+}
+
+// clearTTL removes any TTL bookkeeping for key.
+func (c *Collection[V]) clearTTL(key string) {
+	c.ttlMu.Lock()
+	defer c.ttlMu.Unlock()
+	item, ok := c.expiryItems[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&c.expiry, item.index)
+	delete(c.expiryItems, key)
+	delete(c.ttlDurations, key)
+}
+
+// EnableSweepInterval starts a background goroutine that removes expired
+// entries, invoking OnExpire for each. The sweeper wakes as soon as the
+// next entry is due rather than scanning the whole collection every tick,
+// falling back to interval d when nothing is currently set to expire.
+// Calling it again while a sweep is already running is a no-op.
+func (c *Collection[V]) EnableSweepInterval(d time.Duration) {
+	c.ttlMu.Lock()
+	if c.sweepStop != nil {
+		c.ttlMu.Unlock()
+		return
+	}
+	c.sweepInterval = d
+	stop := make(chan struct{})
+	c.sweepStop = stop
+	c.ttlMu.Unlock()
+
+	c.sweepWG.Add(1)
+	go c.sweepLoop(stop)
+}
+
+// StopSweep stops the background sweeper started by EnableSweepInterval and
+// waits for it to exit. It is a no-op if no sweeper is running.
+func (c *Collection[V]) StopSweep() {
+	c.ttlMu.Lock()
+	stop := c.sweepStop
+	c.sweepStop = nil
+	c.ttlMu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	c.sweepWG.Wait()
+}
+
+func (c *Collection[V]) sweepLoop(stop chan struct{}) {
+	defer c.sweepWG.Done()
+	timer := time.NewTimer(c.nextSweepWait())
+	defer timer.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+			c.sweepExpired()
+			timer.Reset(c.nextSweepWait())
+		}
+	}
+}
+
+// nextSweepWait returns how long the sweeper should sleep before its next pass.
+func (c *Collection[V]) nextSweepWait() time.Duration {
+	c.ttlMu.Lock()
+	defer c.ttlMu.Unlock()
+	if c.expiry.Len() == 0 {
+		return c.sweepInterval
+	}
+	wait := time.Until(c.expiry[0].deadline)
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > c.sweepInterval {
+		wait = c.sweepInterval
+	}
+	return wait
+}
+
+// sweepExpired removes every entry whose deadline has passed and reports it
+// to OnExpire.
+func (c *Collection[V]) sweepExpired() {
+	now := time.Now()
+
+	c.ttlMu.Lock()
+	var expired []string
+	for c.expiry.Len() > 0 && !c.expiry[0].deadline.After(now) {
+		item := heap.Pop(&c.expiry).(*expiryItem)
+		delete(c.expiryItems, item.key)
+		delete(c.ttlDurations, item.key)
+		expired = append(expired, item.key)
+	}
+	c.ttlMu.Unlock()
+
+	if len(expired) == 0 {
+		return
+	}
+
+	values := make([]V, len(expired))
+	c.m.Lock()
+	for i, key := range expired {
+		values[i] = c.data[key]
+		delete(c.data, key)
+	}
+	c.m.Unlock()
+
+	for _, key := range expired {
+		c.removeFromPolicy(key)
+	}
+
+	if c.OnExpire == nil {
+		return
+	}
+	for i, key := range expired {
+		c.OnExpire(key, values[i])
+	}
+}
@@ -0,0 +1,97 @@
+package collection
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClearResetsTTLBookkeeping(t *testing.T) {
+	c := New[string](0)
+	c.SetWithTTL("k", "stale", 20*time.Millisecond)
+	c.Clear()
+	c.Set("k", "fresh")
+
+	time.Sleep(30 * time.Millisecond)
+
+	if v := c.Get("k"); v != "fresh" {
+		t.Fatalf("Get(k) = %q, want %q (Clear should have dropped the stale TTL)", v, "fresh")
+	}
+}
+
+func TestSetWithTTLExpiresValue(t *testing.T) {
+	c := New[int](0)
+	c.SetWithTTL("a", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if c.Contains("a") {
+		t.Fatal("expected expired key to be absent from Contains")
+	}
+	if v := c.Get("a"); v != 0 {
+		t.Fatalf("Get(expired) = %d, want zero value", v)
+	}
+}
+
+func TestTTLReportsRemainingTime(t *testing.T) {
+	c := New[int](0)
+	c.SetWithTTL("a", 1, time.Minute)
+
+	ttl, ok := c.TTL("a")
+	if !ok {
+		t.Fatal("TTL(a) ok = false, want true")
+	}
+	if ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("TTL(a) = %v, want in (0, 1m]", ttl)
+	}
+
+	if _, ok := c.TTL("missing"); ok {
+		t.Fatal("TTL(missing) ok = true, want false")
+	}
+}
+
+func TestTouchExtendsDeadline(t *testing.T) {
+	c := New[int](0)
+	c.SetWithTTL("a", 1, 10*time.Millisecond)
+	time.Sleep(6 * time.Millisecond)
+	c.Touch("a")
+	time.Sleep(6 * time.Millisecond)
+
+	if !c.Contains("a") {
+		t.Fatal("expected Touch to extend the deadline past the original TTL")
+	}
+}
+
+func TestEnableSweepIntervalInvokesOnExpire(t *testing.T) {
+	c := New[int](0)
+	expired := make(chan string, 1)
+	c.OnExpire = func(key string, value int) {
+		expired <- key
+	}
+
+	c.SetWithTTL("a", 1, 5*time.Millisecond)
+	c.EnableSweepInterval(5 * time.Millisecond)
+	defer c.StopSweep()
+
+	select {
+	case key := <-expired:
+		if key != "a" {
+			t.Fatalf("OnExpire key = %q, want %q", key, "a")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnExpire was not called within 1s")
+	}
+
+	if c.Contains("a") {
+		t.Fatal("expected sweeper to remove the expired key from the collection")
+	}
+}
+
+func TestStopSweepStopsBackgroundGoroutine(t *testing.T) {
+	c := New[int](0)
+	c.EnableSweepInterval(time.Millisecond)
+	c.StopSweep()
+
+	// A second StopSweep or EnableSweepInterval call should not hang or panic.
+	c.StopSweep()
+	c.EnableSweepInterval(time.Millisecond)
+	c.StopSweep()
+}